@@ -0,0 +1,264 @@
+package nyaa
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// parseTorrentInfo decodes the bencoded metainfo of a .torrent file.
+func parseTorrentInfo(data []byte) (*TorrentInfo, error) {
+	decoder := &bencodeDecoder{data: data}
+	top, infoHash, infoMap, err := decoder.decodeTopLevelDict()
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding metainfo dict")
+	}
+
+	announce, _ := top["announce"].(string)
+
+	var announceList [][]string
+	if tiers, ok := top["announce-list"].([]interface{}); ok {
+		for _, tier := range tiers {
+			tierList, ok := tier.([]interface{})
+			if !ok {
+				continue
+			}
+			var urls []string
+			for _, u := range tierList {
+				if s, ok := u.(string); ok {
+					urls = append(urls, s)
+				}
+			}
+			announceList = append(announceList, urls)
+		}
+	}
+
+	name, _ := infoMap["name"].(string)
+	pieceLength, _ := infoMap["piece length"].(int64)
+
+	files, err := decodeTorrentFiles(infoMap, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TorrentInfo{
+		InfoHash:     infoHash,
+		Name:         name,
+		PieceLength:  pieceLength,
+		Files:        files,
+		Announce:     announce,
+		AnnounceList: announceList,
+	}, nil
+}
+
+func decodeTorrentFiles(infoMap map[string]interface{}, name string) ([]TorrentFile, error) {
+	if filesList, ok := infoMap["files"].([]interface{}); ok {
+		files := make([]TorrentFile, 0, len(filesList))
+		for _, f := range filesList {
+			fileMap, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("unexpected layout: file entry is not a dict")
+			}
+			length, _ := fileMap["length"].(int64)
+
+			pathParts, _ := fileMap["path"].([]interface{})
+			parts := make([]string, 0, len(pathParts))
+			for _, p := range pathParts {
+				if s, ok := p.(string); ok {
+					parts = append(parts, s)
+				}
+			}
+
+			files = append(files, TorrentFile{Path: strings.Join(parts, "/"), Length: length})
+		}
+		return files, nil
+	}
+
+	if length, ok := infoMap["length"].(int64); ok {
+		return []TorrentFile{{Path: name, Length: length}}, nil
+	}
+
+	return nil, errors.New("unexpected layout: info dict has neither files nor length")
+}
+
+// bencodeDecoder decodes the bencode format used by .torrent metainfo files.
+type bencodeDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *bencodeDecoder) decode() (interface{}, error) {
+	if d.pos >= len(d.data) {
+		return nil, errors.New("unexpected end of bencoded data")
+	}
+
+	switch d.data[d.pos] {
+	case 'd':
+		return d.decodeDict()
+	case 'l':
+		return d.decodeList()
+	case 'i':
+		return d.decodeInt()
+	default:
+		return d.decodeString()
+	}
+}
+
+// decodeTopLevelDict decodes the metainfo's top-level dict, additionally returning the
+// SHA-1 hash of the "info" value's own bencoded bytes (needed for the torrent's info
+// hash) and the decoded info dict itself. The span of the info value is captured while
+// it is being decoded, rather than re-located afterwards by scanning for a literal
+// "4:info" marker, which could also match inside an earlier string value.
+func (d *bencodeDecoder) decodeTopLevelDict() (map[string]interface{}, string, map[string]interface{}, error) {
+	if d.pos >= len(d.data) || d.data[d.pos] != 'd' {
+		return nil, "", nil, errors.New("expected a bencoded dict")
+	}
+	d.pos++
+
+	top := map[string]interface{}{}
+	var infoHash string
+	var infoMap map[string]interface{}
+
+	for {
+		if d.pos >= len(d.data) {
+			return nil, "", nil, errors.New("unexpected end of bencoded dict")
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			break
+		}
+
+		key, err := d.decodeString()
+		if err != nil {
+			return nil, "", nil, errors.Wrap(err, "error decoding dict key")
+		}
+
+		if key == "info" {
+			start := d.pos
+			value, err := d.decodeDict()
+			if err != nil {
+				return nil, "", nil, errors.Wrap(err, "error decoding info dict")
+			}
+			hash := sha1.Sum(d.data[start:d.pos])
+			infoHash = hex.EncodeToString(hash[:])
+			infoMap = value
+			top[key] = value
+			continue
+		}
+
+		value, err := d.decode()
+		if err != nil {
+			return nil, "", nil, errors.Wrap(err, "error decoding dict value")
+		}
+		top[key] = value
+	}
+
+	if infoMap == nil {
+		return nil, "", nil, errors.New("unexpected layout: metainfo is missing an info dict")
+	}
+
+	return top, infoHash, infoMap, nil
+}
+
+func (d *bencodeDecoder) decodeDict() (map[string]interface{}, error) {
+	if d.pos >= len(d.data) || d.data[d.pos] != 'd' {
+		return nil, errors.New("expected a bencoded dict")
+	}
+	d.pos++
+
+	dict := map[string]interface{}{}
+	for {
+		if d.pos >= len(d.data) {
+			return nil, errors.New("unexpected end of bencoded dict")
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			return dict, nil
+		}
+
+		key, err := d.decodeString()
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding dict key")
+		}
+		value, err := d.decode()
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding dict value")
+		}
+		dict[key] = value
+	}
+}
+
+func (d *bencodeDecoder) decodeList() ([]interface{}, error) {
+	if d.pos >= len(d.data) || d.data[d.pos] != 'l' {
+		return nil, errors.New("expected a bencoded list")
+	}
+	d.pos++
+
+	var list []interface{}
+	for {
+		if d.pos >= len(d.data) {
+			return nil, errors.New("unexpected end of bencoded list")
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			return list, nil
+		}
+
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+}
+
+func (d *bencodeDecoder) decodeInt() (int64, error) {
+	if d.pos >= len(d.data) || d.data[d.pos] != 'i' {
+		return 0, errors.New("expected a bencoded integer")
+	}
+	d.pos++
+
+	start := d.pos
+	for d.pos < len(d.data) && d.data[d.pos] != 'e' {
+		d.pos++
+	}
+	if d.pos >= len(d.data) {
+		return 0, errors.New("unexpected end of bencoded integer")
+	}
+
+	n, err := strconv.ParseInt(string(d.data[start:d.pos]), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "error parsing bencoded integer")
+	}
+	d.pos++ // consume 'e'
+
+	return n, nil
+}
+
+func (d *bencodeDecoder) decodeString() (string, error) {
+	start := d.pos
+	for d.pos < len(d.data) && d.data[d.pos] != ':' {
+		d.pos++
+	}
+	if d.pos >= len(d.data) {
+		return "", errors.New("unexpected end of bencoded string length")
+	}
+
+	length, err := strconv.Atoi(string(d.data[start:d.pos]))
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing bencoded string length")
+	}
+	d.pos++ // consume ':'
+
+	if length < 0 || d.pos+length > len(d.data) {
+		return "", errors.New("bencoded string length exceeds data")
+	}
+
+	s := string(d.data[d.pos : d.pos+length])
+	d.pos += length
+
+	return s, nil
+}
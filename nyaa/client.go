@@ -0,0 +1,399 @@
+package nyaa
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+// defaultClient is used by the package-level Search, GetByID, and Download helpers.
+var defaultClient = NewClient()
+
+// defaultMirrorCooldown is how long a mirror is skipped after a failed request, unless
+// overridden with WithMirrorCooldown.
+const defaultMirrorCooldown = 5 * time.Minute
+
+// Client holds everything needed to talk to nyaa.si: the underlying *http.Client, a base
+// URL, a User-Agent, and optional rate limiting, retries, and response caching. The
+// package-level Search, GetByID, and Download functions are thin wrappers around a
+// default Client; construct one directly with NewClient to customize any of this.
+type Client struct {
+	httpClient     *http.Client
+	baseURL        string
+	mirrors        []string
+	mirrorCooldown time.Duration
+	health         *mirrorHealth
+	userAgent      string
+	limiter        *rate.Limiter
+	maxRetries     int
+	cache          *responseCache
+}
+
+// Option configures a Client constructed with NewClient.
+type Option func(*Client)
+
+// WithHTTPClient overrides the *http.Client used for requests, e.g. to set a timeout,
+// proxy, or transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithBaseURL overrides the nyaa instance the client talks to. Defaults to NyaaURL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = baseURL }
+}
+
+// WithSite points the client at a Site, e.g. SiteNyaa or SiteSukebei, instead of a raw
+// base URL.
+func WithSite(site Site) Option {
+	return func(c *Client) { c.baseURL = site.baseURL() }
+}
+
+// WithMirrors adds fallback base URLs tried, in order, after the client's primary base
+// URL when a request fails with a network error or a non-2xx response. A mirror that
+// fails is skipped for the cooldown set by WithMirrorCooldown (5 minutes by default).
+func WithMirrors(mirrors ...string) Option {
+	return func(c *Client) { c.mirrors = mirrors }
+}
+
+// WithMirrorCooldown overrides how long a failing mirror is skipped for. Defaults to 5
+// minutes.
+func WithMirrorCooldown(cooldown time.Duration) Option {
+	return func(c *Client) { c.mirrorCooldown = cooldown }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// WithRateLimit throttles outgoing requests to r requests per second, allowing bursts up
+// to burst.
+func WithRateLimit(r rate.Limit, burst int) Option {
+	return func(c *Client) { c.limiter = rate.NewLimiter(r, burst) }
+}
+
+// WithMaxRetries sets how many times a request is retried after a 429 or 5xx response,
+// with a linear backoff between attempts. Defaults to 3.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Client) { c.maxRetries = maxRetries }
+}
+
+// WithCache enables an on-disk response cache under dir, keyed by request URL, with
+// entries expiring after ttl.
+func WithCache(dir string, ttl time.Duration) Option {
+	return func(c *Client) { c.cache = &responseCache{dir: dir, ttl: ttl} }
+}
+
+// NewClient builds a Client with sane defaults, customized by opts.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient:     http.DefaultClient,
+		baseURL:        NyaaURL,
+		userAgent:      "nyaa-go",
+		maxRetries:     3,
+		mirrorCooldown: defaultMirrorCooldown,
+		health:         newMirrorHealth(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// get builds a URL against each of the client's base URL and mirrors, in order, and
+// returns the body of the first one that succeeds. A base URL that fails is marked down;
+// a mirror that is marked down is skipped for the mirror cooldown period, while the
+// primary base URL is still tried first on every request.
+func (c *Client) get(ctx context.Context, buildURL func(baseURL string) (string, error)) ([]byte, error) {
+	var lastErr error
+	tried := false
+
+	for _, baseURL := range c.candidateBaseURLs() {
+		URL, err := buildURL(baseURL)
+		if err != nil {
+			return nil, err
+		}
+
+		tried = true
+		body, err := c.fetchURL(ctx, URL)
+		if err == nil {
+			return body, nil
+		}
+
+		c.health.markDown(baseURL, c.mirrorCooldown)
+		lastErr = err
+	}
+
+	if !tried {
+		return nil, errors.New("no healthy base URL available")
+	}
+
+	return nil, errors.Wrap(lastErr, "all mirrors failed")
+}
+
+// getStream is the streaming equivalent of get: it tries the client's base URL and
+// mirrors in order, but instead of buffering the response into memory it copies it
+// directly into a writer obtained from newWriter. newWriter is called fresh for every
+// attempt, so a destination backed by a file is reopened (and truncated) rather than
+// appended to if an earlier attempt failed partway through.
+func (c *Client) getStream(ctx context.Context, buildURL func(baseURL string) (string, error), newWriter func() (io.WriteCloser, error)) error {
+	var lastErr error
+	tried := false
+
+	for _, baseURL := range c.candidateBaseURLs() {
+		URL, err := buildURL(baseURL)
+		if err != nil {
+			return err
+		}
+
+		tried = true
+		err = c.streamURL(ctx, URL, newWriter)
+		if err == nil {
+			return nil
+		}
+
+		c.health.markDown(baseURL, c.mirrorCooldown)
+		lastErr = err
+	}
+
+	if !tried {
+		return errors.New("no healthy base URL available")
+	}
+
+	return errors.Wrap(lastErr, "all mirrors failed")
+}
+
+// streamURL applies the client's rate limit and retry-with-backoff policy around
+// streamOnce.
+func (c *Client) streamURL(ctx context.Context, rawURL string, newWriter func() (io.WriteCloser, error)) error {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return errors.Wrap(err, "error waiting for rate limiter")
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * time.Second):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		retryable, err := c.streamOnce(ctx, rawURL, newWriter)
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+		if !retryable {
+			return err
+		}
+	}
+
+	return errors.Wrap(lastErr, "exhausted retries")
+}
+
+// streamOnce performs a single streaming request attempt, copying the response body
+// straight into a freshly opened writer. The bool return reports whether the error, if
+// any, is worth retrying.
+func (c *Client) streamOnce(ctx context.Context, rawURL string, newWriter func() (io.WriteCloser, error)) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "error creating request")
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	rep, err := c.httpClient.Do(req)
+	if err != nil {
+		return true, errors.Wrap(err, "error performing request")
+	}
+	defer rep.Body.Close()
+
+	if rep.StatusCode == http.StatusTooManyRequests || rep.StatusCode >= 500 {
+		return true, errors.Errorf("retryable HTTP status code: %d %s", rep.StatusCode, rep.Status)
+	}
+	if rep.StatusCode < 200 || rep.StatusCode >= 300 {
+		return false, errors.Errorf("non-OK HTTP status code: %d %s", rep.StatusCode, rep.Status)
+	}
+
+	w, err := newWriter()
+	if err != nil {
+		return false, errors.Wrap(err, "error opening destination writer")
+	}
+	defer w.Close()
+
+	if _, err := io.Copy(w, rep.Body); err != nil {
+		return true, errors.Wrap(err, "error streaming response body")
+	}
+
+	return false, nil
+}
+
+// candidateBaseURLs returns the client's base URL followed by its mirrors, skipping any
+// currently in their failure cooldown. The primary base URL is always tried, even if
+// marked down, so a client with no mirrors still works identically to before.
+func (c *Client) candidateBaseURLs() []string {
+	candidates := []string{c.baseURL}
+	for _, mirror := range c.mirrors {
+		if !c.health.isDown(mirror) {
+			candidates = append(candidates, mirror)
+		}
+	}
+	return candidates
+}
+
+// mirrorHealth tracks mirrors that recently failed so they can be skipped for a cooldown
+// period instead of being retried on every request.
+type mirrorHealth struct {
+	mu        sync.Mutex
+	downUntil map[string]time.Time
+}
+
+func newMirrorHealth() *mirrorHealth {
+	return &mirrorHealth{downUntil: map[string]time.Time{}}
+}
+
+func (h *mirrorHealth) isDown(baseURL string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Now().Before(h.downUntil[baseURL])
+}
+
+func (h *mirrorHealth) markDown(baseURL string, cooldown time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.downUntil[baseURL] = time.Now().Add(cooldown)
+}
+
+// fetchURL fetches rawURL, applying the client's rate limit, cache, and retry-with-backoff
+// policy, and returns the response body.
+func (c *Client) fetchURL(ctx context.Context, rawURL string) ([]byte, error) {
+	if c.cache != nil {
+		if body, ok := c.cache.get(rawURL); ok {
+			return body, nil
+		}
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, errors.Wrap(err, "error waiting for rate limiter")
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * time.Second):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		body, retryable, err := c.getOnce(ctx, rawURL)
+		if err == nil {
+			if c.cache != nil {
+				// Caching is best-effort: a request that already succeeded shouldn't
+				// fail just because the cache couldn't be written to.
+				_ = c.cache.set(rawURL, body)
+			}
+			return body, nil
+		}
+
+		lastErr = err
+		if !retryable {
+			return nil, err
+		}
+	}
+
+	return nil, errors.Wrap(lastErr, "exhausted retries")
+}
+
+// getOnce performs a single request attempt. The bool return reports whether the error,
+// if any, is worth retrying (network errors and 429/5xx responses are; anything else
+// isn't).
+func (c *Client) getOnce(ctx context.Context, rawURL string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "error creating request")
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	rep, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, true, errors.Wrap(err, "error performing request")
+	}
+	defer rep.Body.Close()
+
+	body, err := io.ReadAll(rep.Body)
+	if err != nil {
+		return nil, true, errors.Wrap(err, "error reading response body")
+	}
+
+	if rep.StatusCode == http.StatusTooManyRequests || rep.StatusCode >= 500 {
+		return nil, true, errors.Errorf("retryable HTTP status code: %d %s", rep.StatusCode, rep.Status)
+	}
+	if rep.StatusCode < 200 || rep.StatusCode >= 300 {
+		return nil, false, errors.Errorf("non-OK HTTP status code: %d %s", rep.StatusCode, rep.Status)
+	}
+
+	return body, false, nil
+}
+
+// responseCache is a simple on-disk cache keyed by request URL, storing the time it was
+// written so entries can expire after ttl.
+type responseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+func (rc *responseCache) path(rawURL string) string {
+	sum := sha1.Sum([]byte(rawURL))
+	return filepath.Join(rc.dir, hex.EncodeToString(sum[:])+".cache")
+}
+
+func (rc *responseCache) get(rawURL string) ([]byte, bool) {
+	data, err := os.ReadFile(rc.path(rawURL))
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+
+	storedAt := time.Unix(int64(binary.BigEndian.Uint64(data[:8])), 0)
+	if time.Since(storedAt) > rc.ttl {
+		return nil, false
+	}
+
+	return data[8:], true
+}
+
+func (rc *responseCache) set(rawURL string, body []byte) error {
+	if err := os.MkdirAll(rc.dir, 0o755); err != nil {
+		return errors.Wrap(err, "error creating cache directory")
+	}
+
+	entry := make([]byte, 8+len(body))
+	binary.BigEndian.PutUint64(entry[:8], uint64(time.Now().Unix()))
+	copy(entry[8:], body)
+
+	return os.WriteFile(rc.path(rawURL), entry, 0o644)
+}
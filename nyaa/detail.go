@@ -0,0 +1,190 @@
+package nyaa
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+// MediaDetails is the extended metadata found on a torrent's detail page, not present in
+// the search listing.
+type MediaDetails struct {
+	Media
+	Submitter      string
+	InformationURL string
+	Description    string
+	Files          []MediaFileEntry
+	Comments       []Comment
+}
+
+// MediaFileEntry is a single file listed in a torrent's file tree.
+type MediaFileEntry struct {
+	Name string
+	Size uint64
+}
+
+// Comment is a single comment left on a torrent's detail page.
+type Comment struct {
+	Author    string
+	Timestamp time.Time
+	Body      string
+}
+
+// GetByID fetches and parses the detail page for the torrent with the given id, using the
+// default client.
+func GetByID(id uint) (*MediaDetails, error) {
+	return defaultClient.GetByID(id)
+}
+
+// GetByID fetches and parses the detail page for the torrent with the given id.
+func (c *Client) GetByID(id uint) (*MediaDetails, error) {
+	doc, err := c.requestDetailHTML(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting the nyaa detail page")
+	}
+
+	details, err := parseDetailPageHTML(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing html")
+	}
+	details.ID = id
+
+	return details, nil
+}
+
+// FetchDetails fetches the detail page for m and returns the full MediaDetails, with its
+// embedded Media set to m. The detail page only adds Submitter/InformationURL/
+// Description/Files/Comments; it doesn't restate the listing fields, so m itself is left
+// untouched rather than being overwritten with an almost-empty Media.
+func (m *Media) FetchDetails() (*MediaDetails, error) {
+	details, err := GetByID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	details.Media = *m
+
+	return details, nil
+}
+
+func (c *Client) requestDetailHTML(id uint) (*goquery.Document, error) {
+	body, err := c.get(context.Background(), func(baseURL string) (string, error) {
+		return baseURL + "/view/" + strconv.FormatUint(uint64(id), 10), nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting detail page")
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing response html")
+	}
+
+	return doc, nil
+}
+
+func parseDetailPageHTML(doc *goquery.Document) (*MediaDetails, error) {
+	details := &MediaDetails{}
+
+	details.Submitter = strings.TrimSpace(doc.Find("a[href^='/user/']").First().Text())
+
+	infoLink, ok := doc.Find(".panel-body a[rel~=\"noreferrer\"]").First().Attr("href")
+	if ok {
+		details.InformationURL = infoLink
+	}
+
+	details.Description = strings.TrimSpace(doc.Find("#torrent-description").Text())
+
+	files, err := parseDetailFiles(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing file list")
+	}
+	details.Files = files
+
+	comments, err := parseDetailComments(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing comments")
+	}
+	details.Comments = comments
+
+	return details, nil
+}
+
+func parseDetailFiles(doc *goquery.Document) ([]MediaFileEntry, error) {
+	var files []MediaFileEntry
+	var parseErr error
+
+	doc.Find(".torrent-file-list li").Each(func(_ int, s *goquery.Selection) {
+		if parseErr != nil {
+			return
+		}
+
+		sizeSel := s.Find(".file-size")
+		sizeText := strings.Trim(sizeSel.Text(), "() ")
+		if sizeText == "" {
+			return
+		}
+
+		nameSel := s.Clone()
+		nameSel.Find(".file-size").Remove()
+		name := strings.TrimSpace(nameSel.Text())
+
+		size, err := units.FromHumanSize(sizeText)
+		if err != nil {
+			parseErr = errors.Wrap(err, "error parsing file size")
+			return
+		}
+
+		files = append(files, MediaFileEntry{Name: name, Size: uint64(size)})
+	})
+
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return files, nil
+}
+
+func parseDetailComments(doc *goquery.Document) ([]Comment, error) {
+	var comments []Comment
+	var parseErr error
+
+	doc.Find(".comment-panel").Each(func(_ int, s *goquery.Selection) {
+		if parseErr != nil {
+			return
+		}
+
+		author := strings.TrimSpace(s.Find(".comment-username").Text())
+
+		timestampAttr, ok := s.Find("[data-timestamp]").Attr("data-timestamp")
+		if !ok {
+			parseErr = errors.New("unexpected layout: comment missing a data-timestamp")
+			return
+		}
+		timestamp, err := strconv.Atoi(timestampAttr)
+		if err != nil {
+			parseErr = errors.Wrap(err, "error parsing comment timestamp")
+			return
+		}
+
+		body := strings.TrimSpace(s.Find(".comment-content").Text())
+
+		comments = append(comments, Comment{
+			Author:    author,
+			Timestamp: time.Unix(int64(timestamp), 0),
+			Body:      body,
+		})
+	})
+
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return comments, nil
+}
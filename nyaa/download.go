@@ -0,0 +1,102 @@
+package nyaa
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// TorrentInfo is the parsed metainfo of a .torrent file.
+type TorrentInfo struct {
+	InfoHash     string
+	Name         string
+	PieceLength  int64
+	Files        []TorrentFile
+	Announce     string
+	AnnounceList [][]string
+}
+
+// TorrentFile describes a single file contained in a torrent.
+type TorrentFile struct {
+	Path   string
+	Length int64
+}
+
+// Download fetches the .torrent file for m and saves it into dir, using the default
+// client, returning the path to the downloaded file.
+func (m Media) Download(dir string) (string, error) {
+	return m.DownloadContext(context.Background(), dir)
+}
+
+// DownloadContext is like Download but allows the caller to cancel the request via ctx.
+func (m Media) DownloadContext(ctx context.Context, dir string) (string, error) {
+	return defaultClient.Download(ctx, m, dir)
+}
+
+// Download streams the .torrent file for m straight to disk under dir, returning the
+// path to the downloaded file. The response is never buffered in memory, so ctx can
+// cancel an in-progress download of a large file just as well as one that hasn't started.
+func (c *Client) Download(ctx context.Context, m Media, dir string) (string, error) {
+	path := filepath.Join(dir, fmt.Sprintf("%d.torrent", m.ID))
+
+	err := c.getStream(ctx, func(baseURL string) (string, error) {
+		return resolveURL(baseURL, m.Torrent)
+	}, func() (io.WriteCloser, error) {
+		return os.Create(path)
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "error downloading torrent file")
+	}
+
+	return path, nil
+}
+
+// FetchTorrentInfo downloads and parses the bencoded metainfo of m's .torrent file, using
+// the default client.
+func (m Media) FetchTorrentInfo() (*TorrentInfo, error) {
+	return m.FetchTorrentInfoContext(context.Background())
+}
+
+// FetchTorrentInfoContext is like FetchTorrentInfo but allows the caller to cancel the
+// request via ctx.
+func (m Media) FetchTorrentInfoContext(ctx context.Context) (*TorrentInfo, error) {
+	return defaultClient.FetchTorrentInfo(ctx, m)
+}
+
+// FetchTorrentInfo downloads and parses the bencoded metainfo of m's .torrent file.
+func (c *Client) FetchTorrentInfo(ctx context.Context, m Media) (*TorrentInfo, error) {
+	body, err := c.get(ctx, func(baseURL string) (string, error) {
+		return resolveURL(baseURL, m.Torrent)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting torrent file")
+	}
+
+	info, err := parseTorrentInfo(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing torrent metainfo")
+	}
+
+	return info, nil
+}
+
+// resolveURL resolves ref (which may be a relative href like "/download/1.torrent", or
+// already absolute) against baseURL.
+func resolveURL(baseURL, ref string) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing base url")
+	}
+
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing url")
+	}
+
+	return base.ResolveReference(refURL).String(), nil
+}
@@ -1,11 +1,12 @@
 package nyaa
 
 import (
+	"bytes"
+	"context"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"golang.org/x/net/html"
-	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -15,13 +16,41 @@ import (
 
 var NyaaURL = "https://nyaa.si"
 
+// Search runs a search against the default client. See Client.Search.
 func Search(search string, parameters ...SearchParameters) ([]Media, error) {
+	return defaultClient.Search(search, parameters...)
+}
+
+// Search resolves a query against whichever Backend params selects, defaulting to
+// BackendHTML.
+func (c *Client) Search(search string, parameters ...SearchParameters) ([]Media, error) {
 	params, err := getOneParameterSet(parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	doc, err := requestHTML(search, params)
+	backend := params.Backend
+	if backend == nil {
+		backend = BackendHTML
+	}
+
+	return backend.search(c, search, params)
+}
+
+// Backend fetches and parses a search result set. BackendHTML scrapes the nyaa.si HTML
+// listing; BackendRSS consumes its RSS feed instead. Set SearchParameters.Backend to pick
+// one explicitly; it defaults to BackendHTML.
+type Backend interface {
+	search(c *Client, search string, params SearchParameters) ([]Media, error)
+}
+
+// BackendHTML scrapes the HTML search results page. This is the default backend.
+var BackendHTML Backend = htmlBackend{}
+
+type htmlBackend struct{}
+
+func (htmlBackend) search(c *Client, search string, params SearchParameters) ([]Media, error) {
+	doc, err := c.requestHTML(search, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting the nyaa page")
 	}
@@ -46,23 +75,15 @@ func getOneParameterSet(parameters []SearchParameters) (SearchParameters, error)
 	return params, nil
 }
 
-func requestHTML(search string, params SearchParameters) (*goquery.Document, error) {
-	URL, err := urlForParams(search, params)
-	if err != nil {
-		return nil, errors.Wrap(err, "error creating url for search")
-	}
-
-	rep, err := http.Get(URL)
+func (c *Client) requestHTML(search string, params SearchParameters) (*goquery.Document, error) {
+	body, err := c.get(context.Background(), func(baseURL string) (string, error) {
+		return urlForParams(baseURL, search, params)
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "error requesting results")
 	}
-	defer rep.Body.Close()
-
-	if rep.StatusCode < 200 || rep.StatusCode >= 300 {
-		return nil, errors.Errorf("non-OK HTTP status code: %d %s", rep.StatusCode, rep.Status)
-	}
 
-	doc, err := goquery.NewDocumentFromReader(rep.Body)
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
 	if err != nil {
 		return nil, errors.Wrap(err, "error parsing response html")
 	}
@@ -70,13 +91,12 @@ func requestHTML(search string, params SearchParameters) (*goquery.Document, err
 	return doc, nil
 }
 
-func urlForParams(search string, parameters SearchParameters) (string, error) {
-	baseURL := NyaaURL
+func urlForParams(baseURL string, search string, parameters SearchParameters) (string, error) {
 	if parameters.User != "" {
 		baseURL += "/user/" + url.PathEscape(parameters.User)
 	}
 
-	URL, err := url.Parse(NyaaURL)
+	URL, err := url.Parse(baseURL)
 	if err != nil {
 		return "", errors.Wrap(err, "error parsing nyaa url")
 	}
@@ -87,6 +107,9 @@ func urlForParams(search string, parameters SearchParameters) (string, error) {
 	query.Set("q", search)
 	query.Set("s", string(parameters.SortBy))
 	query.Set("o", string(parameters.SortOrder))
+	if parameters.Page != 0 {
+		query.Set("p", strconv.Itoa(parameters.Page))
+	}
 	URL.RawQuery = query.Encode()
 
 	return URL.String(), nil
@@ -0,0 +1,87 @@
+package nyaa
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// resultsPerPage is the number of rows nyaa.si returns for a full page. A page with fewer
+// rows than this means there is nothing left to paginate into.
+const resultsPerPage = 75
+
+// SearchAll walks every page of a query against the default client. See Client.SearchAll.
+func SearchAll(ctx context.Context, search string, params SearchParameters) (<-chan Media, <-chan error) {
+	return defaultClient.SearchAll(ctx, search, params)
+}
+
+// SearchAll walks every page of a query, starting at SearchParameters.Page (default 1),
+// until it sees a short page, the caller's MaxResults is reached, or ctx is done. Results
+// are streamed on mediaChan as they arrive; mediaChan and errChan are both closed once the
+// search is over, so callers can range over mediaChan and check errChan afterwards. Every
+// page is fetched through c, so a rate limit, mirrors, or a Sukebei base URL configured on
+// c apply across the whole walk.
+func (c *Client) SearchAll(ctx context.Context, search string, params SearchParameters) (<-chan Media, <-chan error) {
+	mediaChan := make(chan Media)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(mediaChan)
+		defer close(errChan)
+
+		page := params.Page
+		if page == 0 {
+			page = 1
+		}
+
+		seen := 0
+		for {
+			pageParams := params
+			pageParams.Page = page
+
+			medias, err := c.Search(search, pageParams)
+			if err != nil {
+				errChan <- errors.Wrapf(err, "error searching page %d", page)
+				return
+			}
+
+			for _, media := range medias {
+				select {
+				case mediaChan <- media:
+				case <-ctx.Done():
+					errChan <- ctx.Err()
+					return
+				}
+
+				seen++
+				if params.MaxResults != 0 && seen >= params.MaxResults {
+					return
+				}
+			}
+
+			if len(medias) < resultsPerPage {
+				return
+			}
+			page++
+
+			if params.PageDelay > 0 {
+				select {
+				case <-time.After(params.PageDelay):
+				case <-ctx.Done():
+					errChan <- ctx.Err()
+					return
+				}
+			} else {
+				select {
+				case <-ctx.Done():
+					errChan <- ctx.Err()
+					return
+				default:
+				}
+			}
+		}
+	}()
+
+	return mediaChan, errChan
+}
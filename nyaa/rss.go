@@ -0,0 +1,153 @@
+package nyaa
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+// BackendRSS fetches search results from nyaa's RSS feed instead of scraping the HTML
+// listing. It is significantly more resilient to layout changes, at the cost of not
+// exposing anything beyond what the feed publishes.
+var BackendRSS Backend = rssBackend{}
+
+type rssBackend struct{}
+
+func (rssBackend) search(c *Client, search string, params SearchParameters) ([]Media, error) {
+	return c.SearchRSS(search, params)
+}
+
+// SearchRSS is the RSS equivalent of Search, using the default client.
+func SearchRSS(search string, params SearchParameters) ([]Media, error) {
+	return defaultClient.SearchRSS(search, params)
+}
+
+// SearchRSS is the RSS equivalent of Search: it hits nyaa's "?page=rss" endpoint and
+// parses the RSS 2.0 feed, including the Nyaa-specific <nyaa:*> extension elements.
+func (c *Client) SearchRSS(search string, params SearchParameters) ([]Media, error) {
+	body, err := c.get(context.Background(), func(baseURL string) (string, error) {
+		return urlForRSSParams(baseURL, search, params)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting rss feed")
+	}
+
+	feed := rssFeed{}
+	if err := xml.NewDecoder(bytes.NewReader(body)).Decode(&feed); err != nil {
+		return nil, errors.Wrap(err, "error decoding rss feed")
+	}
+
+	medias := make([]Media, len(feed.Channel.Items))
+	for i, item := range feed.Channel.Items {
+		media, err := item.toMedia()
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing rss item")
+		}
+		medias[i] = media
+	}
+
+	return medias, nil
+}
+
+func urlForRSSParams(baseURL string, search string, parameters SearchParameters) (string, error) {
+	if parameters.User != "" {
+		baseURL += "/user/" + url.PathEscape(parameters.User)
+	}
+
+	URL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing nyaa url")
+	}
+
+	query := URL.Query()
+	query.Set("page", "rss")
+	query.Set("f", strconv.FormatInt(int64(parameters.Filter), 10))
+	query.Set("c", string(parameters.Category))
+	query.Set("q", search)
+	query.Set("s", string(parameters.SortBy))
+	query.Set("o", string(parameters.SortOrder))
+	if parameters.Page != 0 {
+		query.Set("p", strconv.Itoa(parameters.Page))
+	}
+	URL.RawQuery = query.Encode()
+
+	return URL.String(), nil
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title      string `xml:"title"`
+	Link       string `xml:"link"`
+	GUID       string `xml:"guid"`
+	PubDate    string `xml:"pubDate"`
+	Seeders    uint   `xml:"seeders"`
+	Leechers   uint   `xml:"leechers"`
+	Downloads  uint   `xml:"downloads"`
+	InfoHash   string `xml:"infoHash"`
+	CategoryID string `xml:"categoryId"`
+	Size       string `xml:"size"`
+}
+
+func (item rssItem) toMedia() (Media, error) {
+	media := Media{}
+
+	id, err := idFromGUID(item.GUID)
+	if err != nil {
+		return media, errors.Wrap(err, "error parsing id from guid")
+	}
+	media.ID = id
+
+	media.Name = item.Title
+	media.Torrent = item.Link
+	media.Magnet = magnetFromInfoHash(item.InfoHash, item.Title)
+	media.InfoHash = item.InfoHash
+	media.Category = Category(item.CategoryID)
+	media.Seeders = item.Seeders
+	media.Leechers = item.Leechers
+	media.Downloads = item.Downloads
+
+	size, err := units.FromHumanSize(item.Size)
+	if err != nil {
+		return media, errors.Wrap(err, "error parsing size")
+	}
+	media.Size = uint64(size)
+
+	date, err := time.Parse(time.RFC1123Z, item.PubDate)
+	if err != nil {
+		return media, errors.Wrap(err, "error parsing pub date")
+	}
+	media.Date = date
+
+	return media, nil
+}
+
+// idFromGUID extracts the torrent id from a view-page GUID, independent of its host, so
+// this works against sukebei and mirrors too and not just NyaaURL.
+func idFromGUID(guid string) (uint, error) {
+	u, err := url.Parse(guid)
+	if err != nil {
+		return 0, errors.Wrap(err, "error parsing guid")
+	}
+	return hrefToID(u.Path)
+}
+
+func magnetFromInfoHash(infoHash, name string) string {
+	values := url.Values{}
+	values.Set("xt", "urn:btih:"+infoHash)
+	values.Set("dn", name)
+	return "magnet:?" + values.Encode()
+}
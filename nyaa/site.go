@@ -0,0 +1,81 @@
+package nyaa
+
+import "time"
+
+// Site identifies a nyaa instance. nyaa.si and sukebei.nyaa.si share the same layout but
+// use entirely different category trees, so each Site has its own category type to stop
+// a nyaa.si category from being used in a sukebei search (or vice versa) at compile time.
+type Site interface {
+	baseURL() string
+}
+
+// SiteNyaa is the general nyaa.si instance. Use it with SearchParameters and Category.
+var SiteNyaa Site = nyaaSite{}
+
+// SiteSukebei is the sukebei.nyaa.si instance. Use it with SukebeiSearchParameters and
+// SukebeiCategory.
+var SiteSukebei Site = sukebeiSite{}
+
+type nyaaSite struct{}
+
+func (nyaaSite) baseURL() string { return "https://nyaa.si" }
+
+type sukebeiSite struct{}
+
+func (sukebeiSite) baseURL() string { return "https://sukebei.nyaa.si" }
+
+// SukebeiCategory is a category ID on sukebei.nyaa.si. It is a distinct type from
+// Category so a nyaa.si category can't be passed to a sukebei search by mistake.
+type SukebeiCategory string
+
+const (
+	SukebeiCategoryAllCategories  SukebeiCategory = "0_0"
+	SukebeiCategoryArtAnime       SukebeiCategory = "1_1"
+	SukebeiCategoryArtDoujinshi   SukebeiCategory = "1_2"
+	SukebeiCategoryArtGames       SukebeiCategory = "1_3"
+	SukebeiCategoryArtManga       SukebeiCategory = "1_4"
+	SukebeiCategoryArtPictures    SukebeiCategory = "1_5"
+	SukebeiCategoryRealLifePhotos SukebeiCategory = "2_1"
+	SukebeiCategoryRealLifeVideos SukebeiCategory = "2_2"
+)
+
+// SukebeiSearchParameters is the sukebei.nyaa.si equivalent of SearchParameters, with a
+// SukebeiCategory instead of a Category.
+type SukebeiSearchParameters struct {
+	User       string
+	Filter     Filter
+	Category   SukebeiCategory
+	SortBy     SortBy
+	SortOrder  SortOrder
+	Page       int
+	MaxResults int
+	PageDelay  time.Duration
+	Backend    Backend
+}
+
+// defaultSukebeiClient is used by the package-level SearchSukebei helper. It is pinned to
+// SiteSukebei so a SukebeiCategory is never sent to nyaa.si.
+var defaultSukebeiClient = NewClient(WithSite(SiteSukebei))
+
+// SearchSukebei is the sukebei.nyaa.si equivalent of Search, using a default client
+// pinned to SiteSukebei.
+func SearchSukebei(search string, params SukebeiSearchParameters) ([]Media, error) {
+	return defaultSukebeiClient.SearchSukebei(search, params)
+}
+
+// SearchSukebei is the sukebei.nyaa.si equivalent of Search. The client must be
+// constructed with WithSite(SiteSukebei) (or an equivalent WithBaseURL), since
+// SukebeiCategory values are meaningless against nyaa.si.
+func (c *Client) SearchSukebei(search string, params SukebeiSearchParameters) ([]Media, error) {
+	return c.Search(search, SearchParameters{
+		User:       params.User,
+		Filter:     params.Filter,
+		Category:   Category(params.Category),
+		SortBy:     params.SortBy,
+		SortOrder:  params.SortOrder,
+		Page:       params.Page,
+		MaxResults: params.MaxResults,
+		PageDelay:  params.PageDelay,
+		Backend:    params.Backend,
+	})
+}